@@ -0,0 +1,14 @@
+// Package api holds the OpenAPI 3 spec for this service, the generated
+// strict ServerInterface (server.gen.go) main.go implements, and the
+// oapi-codegen directives that produced both it and the client package
+// under client/. strict-server wraps a chi.Router (oapi-codegen-server.yaml)
+// rather than std-http-server, since the latter needs Go 1.22's routing
+// patterns and this module targets go 1.21.
+//
+// Run `go generate ./...` (with the tool installed per tools.go) to
+// regenerate server.gen.go and client/client.gen.go after editing
+// openapi.yaml.
+package api
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config oapi-codegen-server.yaml -o server.gen.go openapi.yaml
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config oapi-codegen-client.yaml -o ../client/client.gen.go openapi.yaml
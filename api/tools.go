@@ -0,0 +1,10 @@
+//go:build tools
+
+// This file records the codegen tool as a module dependency (the standard
+// tools.go pattern) so `go generate ./...` always resolves the same
+// oapi-codegen version without it leaking into the built binary.
+package api
+
+import (
+	_ "github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen"
+)
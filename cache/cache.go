@@ -0,0 +1,123 @@
+// Package cache provides a small in-memory TTL cache used to avoid
+// hammering upstream transit APIs with repeated requests for the same key.
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// entry holds a cached value together with the time it was stored and when
+// it expires.
+type entry struct {
+	value    interface{}
+	storedAt time.Time
+	expires  time.Time
+}
+
+// Cache is a keyed, TTL-based in-memory cache. A background goroutine
+// periodically sweeps expired entries so the cache doesn't grow unbounded.
+// The zero value is not usable; construct one with New.
+type Cache struct {
+	ttl   time.Duration
+	mu    sync.RWMutex
+	items map[string]entry
+
+	hits   uint64
+	misses uint64
+
+	stop chan struct{}
+}
+
+// New creates a Cache with the given TTL and starts its background
+// sweeper, which removes expired entries every ttl/2 (but no more often
+// than once a second).
+func New(ttl time.Duration) *Cache {
+	c := &Cache{
+		ttl:   ttl,
+		items: make(map[string]entry),
+		stop:  make(chan struct{}),
+	}
+	go c.sweepLoop()
+	return c
+}
+
+// Get returns the cached value for key and how long ago it was stored, if
+// present and not expired.
+func (c *Cache) Get(key string) (value interface{}, age time.Duration, ok bool) {
+	c.mu.RLock()
+	e, found := c.items[key]
+	c.mu.RUnlock()
+
+	if !found || time.Now().After(e.expires) {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, 0, false
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	return e.value, time.Since(e.storedAt), true
+}
+
+// Set stores value under key, replacing any existing entry and resetting
+// its TTL.
+func (c *Cache) Set(key string, value interface{}) {
+	now := time.Now()
+	c.mu.Lock()
+	c.items[key] = entry{value: value, storedAt: now, expires: now.Add(c.ttl)}
+	c.mu.Unlock()
+}
+
+// TTL returns the duration new entries are considered fresh for.
+func (c *Cache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Hits returns the number of Get calls that found a live entry.
+func (c *Cache) Hits() uint64 {
+	return atomic.LoadUint64(&c.hits)
+}
+
+// Misses returns the number of Get calls that found no live entry.
+func (c *Cache) Misses() uint64 {
+	return atomic.LoadUint64(&c.misses)
+}
+
+// Close stops the background sweeper. It is safe to call Close more than
+// once.
+func (c *Cache) Close() {
+	select {
+	case <-c.stop:
+	default:
+		close(c.stop)
+	}
+}
+
+func (c *Cache) sweepLoop() {
+	interval := c.ttl / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.sweep()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *Cache) sweep() {
+	now := time.Now()
+	c.mu.Lock()
+	for key, e := range c.items {
+		if now.After(e.expires) {
+			delete(c.items, key)
+		}
+	}
+	c.mu.Unlock()
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetSetRoundTrip(t *testing.T) {
+	c := New(time.Minute)
+	defer c.Close()
+
+	if _, _, ok := c.Get("missing"); ok {
+		t.Fatal("Get on empty cache should miss")
+	}
+
+	c.Set("key", "value")
+
+	value, age, ok := c.Get("key")
+	if !ok {
+		t.Fatal("Get after Set should hit")
+	}
+	if value != "value" {
+		t.Fatalf("Get returned %v, want %q", value, "value")
+	}
+	if age < 0 || age > time.Second {
+		t.Fatalf("age = %v, want close to 0", age)
+	}
+}
+
+func TestGetExpired(t *testing.T) {
+	c := New(time.Millisecond)
+	defer c.Close()
+
+	c.Set("key", "value")
+	time.Sleep(10 * time.Millisecond)
+
+	if _, _, ok := c.Get("key"); ok {
+		t.Fatal("Get should miss once the entry's TTL has elapsed")
+	}
+}
+
+func TestHitsAndMisses(t *testing.T) {
+	c := New(time.Minute)
+	defer c.Close()
+
+	c.Set("key", "value")
+	c.Get("key")
+	c.Get("key")
+	c.Get("missing")
+
+	if hits := c.Hits(); hits != 2 {
+		t.Errorf("Hits() = %d, want 2", hits)
+	}
+	if misses := c.Misses(); misses != 1 {
+		t.Errorf("Misses() = %d, want 1", misses)
+	}
+}
+
+func TestTTL(t *testing.T) {
+	c := New(5 * time.Second)
+	defer c.Close()
+
+	if got := c.TTL(); got != 5*time.Second {
+		t.Errorf("TTL() = %v, want 5s", got)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MobilityData/gtfs-realtime-bindings/golang/gtfs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// handleGTFSRT serves the filtered departures for a site as a GTFS-Realtime
+// TripUpdate FeedMessage. By default the response is the binary protobuf
+// encoding; pass ?format=json to get the protobuf-JSON mapping instead,
+// which is handy for debugging without a protobuf decoder on hand.
+func handleGTFSRT(w http.ResponseWriter, r *http.Request) {
+	params, err := parseDepartureQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	departures, _, _, err := resolveDepartures(r.Context(), params)
+	if err != nil {
+		http.Error(w, "Error fetching departure data: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	feed := buildGTFSRTFeed(departures, params.SiteId)
+
+	if r.URL.Query().Get("format") == "json" {
+		body, err := protojson.Marshal(feed)
+		if err != nil {
+			http.Error(w, "Error encoding GTFS-RT feed: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+		return
+	}
+
+	body, err := proto.Marshal(feed)
+	if err != nil {
+		http.Error(w, "Error encoding GTFS-RT feed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Write(body)
+}
+
+// buildGTFSRTFeed converts departures into a GTFS-Realtime FeedMessage
+// containing one TripUpdate per departure, each with a single
+// StopTimeUpdate at requestedSiteID.
+func buildGTFSRTFeed(departures []Departure, requestedSiteID string) *gtfs.FeedMessage {
+	now := uint64(time.Now().Unix())
+
+	entities := make([]*gtfs.FeedEntity, 0, len(departures))
+	for i, d := range departures {
+		scheduled := d.Scheduled.Unix()
+		expected := d.Expected.Unix()
+		delay := int32(expected - scheduled)
+		scheduleRelationship := gtfs.TripUpdate_StopTimeUpdate_SCHEDULED
+
+		stopID := d.SiteID
+		if stopID == "" {
+			stopID = requestedSiteID
+		}
+
+		stopTimeUpdate := &gtfs.TripUpdate_StopTimeUpdate{
+			StopId: proto.String(stopID),
+			Arrival: &gtfs.TripUpdate_StopTimeEvent{
+				Time:  proto.Int64(expected),
+				Delay: proto.Int32(delay),
+			},
+			Departure: &gtfs.TripUpdate_StopTimeEvent{
+				Time:  proto.Int64(expected),
+				Delay: proto.Int32(delay),
+			},
+			ScheduleRelationship: &scheduleRelationship,
+		}
+
+		tripUpdate := &gtfs.TripUpdate{
+			Trip: &gtfs.TripDescriptor{
+				RouteId: proto.String(fmt.Sprintf("%d", d.Line.ID)),
+				TripId:  proto.String(tripID(d)),
+			},
+			StopTimeUpdate: []*gtfs.TripUpdate_StopTimeUpdate{stopTimeUpdate},
+		}
+
+		entities = append(entities, &gtfs.FeedEntity{
+			Id:         proto.String(fmt.Sprintf("%d", i)),
+			TripUpdate: tripUpdate,
+		})
+	}
+
+	return &gtfs.FeedMessage{
+		Header: &gtfs.FeedHeader{
+			GtfsRealtimeVersion: proto.String("2.0"),
+			Timestamp:           proto.Uint64(now),
+		},
+		Entity: entities,
+	}
+}
+
+// tripID synthesizes a stable trip identifier from a departure, since SL's
+// API doesn't expose GTFS trip IDs directly.
+func tripID(d Departure) string {
+	return fmt.Sprintf("%d-%s-%s", d.Line.ID, d.Destination, d.Scheduled.Format("20060102T150405"))
+}
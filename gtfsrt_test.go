@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MobilityData/gtfs-realtime-bindings/golang/gtfs"
+)
+
+func TestBuildGTFSRTFeedDelay(t *testing.T) {
+	scheduled := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+	expected := scheduled.Add(90 * time.Second)
+
+	departures := []Departure{
+		{
+			Destination: "Kungsträdgården",
+			SiteID:      "9001",
+			Scheduled:   CustomTime{Time: scheduled},
+			Expected:    CustomTime{Time: expected},
+			Line:        Line{ID: 14, Designation: "14"},
+		},
+	}
+
+	feed := buildGTFSRTFeed(departures, "9001")
+
+	if len(feed.Entity) != 1 {
+		t.Fatalf("got %d entities, want 1", len(feed.Entity))
+	}
+
+	updates := feed.Entity[0].TripUpdate.StopTimeUpdate
+	if len(updates) != 1 {
+		t.Fatalf("got %d stop time updates, want 1", len(updates))
+	}
+
+	if got, want := updates[0].Arrival.GetDelay(), int32(90); got != want {
+		t.Errorf("delay = %d, want %d", got, want)
+	}
+	if got, want := updates[0].GetStopId(), "9001"; got != want {
+		t.Errorf("StopId = %q, want %q", got, want)
+	}
+}
+
+func TestBuildGTFSRTFeedStopIDFallback(t *testing.T) {
+	scheduled := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+
+	departures := []Departure{
+		{
+			Scheduled: CustomTime{Time: scheduled},
+			Expected:  CustomTime{Time: scheduled},
+			Line:      Line{ID: 1},
+		},
+	}
+
+	feed := buildGTFSRTFeed(departures, "requested-site")
+
+	got := feed.Entity[0].TripUpdate.StopTimeUpdate[0].GetStopId()
+	if got != "requested-site" {
+		t.Errorf("StopId = %q, want fallback to requestedSiteID %q", got, "requested-site")
+	}
+}
+
+func TestBuildGTFSRTFeedScheduleRelationship(t *testing.T) {
+	departures := []Departure{{Scheduled: CustomTime{Time: time.Now()}, Expected: CustomTime{Time: time.Now()}}}
+
+	feed := buildGTFSRTFeed(departures, "site")
+
+	got := feed.Entity[0].TripUpdate.StopTimeUpdate[0].GetScheduleRelationship()
+	if got != gtfs.TripUpdate_StopTimeUpdate_SCHEDULED {
+		t.Errorf("ScheduleRelationship = %v, want SCHEDULED", got)
+	}
+}
@@ -1,143 +1,411 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
+
+	"github.com/mooncos/sl-departures-api/api"
+	"github.com/mooncos/sl-departures-api/cache"
+	"github.com/mooncos/sl-departures-api/provider"
+	"github.com/mooncos/sl-departures-api/provider/entur"
+	"github.com/mooncos/sl-departures-api/provider/idfm"
+	"github.com/mooncos/sl-departures-api/provider/navitia"
+	"github.com/mooncos/sl-departures-api/provider/sl"
 )
 
-type Response struct {
-	Departures []Departure `json:"departures"`
+// Departure and Line are aliased from the provider package so the rest of
+// the module can keep referring to them unqualified.
+type Departure = provider.Departure
+type Line = provider.Line
+type CustomTime = provider.CustomTime
+
+// defaultProviderName selects SL when a request doesn't specify ?provider=.
+const defaultProviderName = "sl"
+
+// providers holds every transit backend this service can proxy to, keyed
+// by the ?provider= query value.
+var providers = map[string]provider.Provider{
+	"sl":      sl.New(),
+	"entur":   entur.New(),
+	"navitia": navitia.New(),
+	"idfm":    idfm.New(),
 }
 
-type Departure struct {
-	Destination string     `json:"destination"`
-	Direction   string     `json:"direction"`
-	Scheduled   CustomTime `json:"scheduled"`
-	Expected    CustomTime `json:"expected"`
-	Line        Line       `json:"line"`
-}
+// departuresCache holds recently fetched departures keyed by the
+// provider's Go type and the siteID, e.g. "*sl.Provider|9192". metadataCache is reserved for stop/line metadata
+// lookups that don't change as often as departures and can tolerate a
+// longer TTL.
+var (
+	departuresCache *cache.Cache
+	metadataCache   *cache.Cache
 
-type Line struct {
-	ID          int    `json:"id"`
-	Designation string `json:"designation"`
+	fanoutConcurrency int
+	fetchTimeout      time.Duration
+)
+
+func main() {
+	departuresTTL := flag.Duration("departures-ttl", envDuration("DEPARTURES_CACHE_TTL", 30*time.Second), "how long to cache upstream departures responses per site")
+	metadataTTL := flag.Duration("metadata-ttl", envDuration("METADATA_CACHE_TTL", 10*time.Minute), "how long to cache stop/line metadata lookups")
+	flag.IntVar(&fanoutConcurrency, "fanout-concurrency", envInt("FANOUT_CONCURRENCY", 8), "max number of sites to fetch concurrently for a multi-site request")
+	flag.DurationVar(&fetchTimeout, "fetch-timeout", envDuration("FETCH_TIMEOUT", 5*time.Second), "timeout for fetching upstream departures for all sites of one request")
+	flag.Parse()
+
+	departuresCache = cache.New(*departuresTTL)
+	metadataCache = cache.New(*metadataTTL)
+
+	apiHandler := api.Handler(api.NewStrictHandler(apiServer{}, nil))
+
+	mux := http.NewServeMux()
+	mux.Handle("/departures", apiHandler)
+	mux.Handle("/departures/json", apiHandler)
+	mux.HandleFunc("/departures/gtfs-rt", handleGTFSRT)
+	mux.HandleFunc("/departures/stream", handleDeparturesStream)
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/openapi.yaml", handleOpenAPISpec)
+	mux.HandleFunc("/docs", handleDocs)
+
+	srv := &http.Server{Addr: ":8080", Handler: mux}
+
+	go func() {
+		fmt.Println("Server is running on http://localhost:8080")
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("server error: %v", err)
+		}
+	}()
+
+	waitForShutdown(srv)
 }
 
-type CustomTime struct {
-	time.Time
+// waitForShutdown blocks until SIGINT or SIGTERM is received, then drains
+// in-flight requests (including long-lived SSE streams) for up to 10
+// seconds before returning.
+func waitForShutdown(srv *http.Server) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+
+	fmt.Println("Shutting down...")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("error during shutdown: %v", err)
+	}
 }
 
-func (ct CustomTime) MarshalJSON() ([]byte, error) {
-	return json.Marshal(ct.Format("2006-01-02T15:04:05"))
+// envDuration reads a duration from the named environment variable,
+// falling back to def if it is unset or invalid.
+func envDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return def
+	}
+	return d
 }
 
-func (ct *CustomTime) UnmarshalJSON(b []byte) error {
-	s := string(b)
-	s = s[1 : len(s)-1]
-	t, err := time.Parse("2006-01-02T15:04:05", s)
+// envInt reads an integer from the named environment variable, falling
+// back to def if it is unset or invalid.
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	i, err := strconv.Atoi(v)
 	if err != nil {
-		return err
+		return def
 	}
-	ct.Time = t
-	return nil
+	return i
 }
 
-const baseURL = "https://transport.integration.sl.se/v1/sites/%s/departures"
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP sl_departures_cache_hits_total Cache hits for the departures cache.\n")
+	fmt.Fprintf(w, "# TYPE sl_departures_cache_hits_total counter\n")
+	fmt.Fprintf(w, "sl_departures_cache_hits_total %d\n", departuresCache.Hits())
+	fmt.Fprintf(w, "# HELP sl_departures_cache_misses_total Cache misses for the departures cache.\n")
+	fmt.Fprintf(w, "# TYPE sl_departures_cache_misses_total counter\n")
+	fmt.Fprintf(w, "sl_departures_cache_misses_total %d\n", departuresCache.Misses())
+	fmt.Fprintf(w, "# HELP sl_metadata_cache_hits_total Cache hits for the metadata cache.\n")
+	fmt.Fprintf(w, "# TYPE sl_metadata_cache_hits_total counter\n")
+	fmt.Fprintf(w, "sl_metadata_cache_hits_total %d\n", metadataCache.Hits())
+	fmt.Fprintf(w, "# HELP sl_metadata_cache_misses_total Cache misses for the metadata cache.\n")
+	fmt.Fprintf(w, "# TYPE sl_metadata_cache_misses_total counter\n")
+	fmt.Fprintf(w, "sl_metadata_cache_misses_total %d\n", metadataCache.Misses())
+}
 
-func main() {
-	http.HandleFunc("/departures", handleDepartures)
-	http.HandleFunc("/departures/json", handleDeparturesJSON)
-	fmt.Println("Server is running on http://localhost:8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+// apiServer implements api.StrictServerInterface. Request validation
+// (required siteId, integer lineId) has already run in
+// api.ServerInterfaceWrapper by the time these methods are called.
+type apiServer struct{}
+
+func (apiServer) GetDepartures(ctx context.Context, request api.GetDeparturesRequestObject) (api.GetDeparturesResponseObject, error) {
+	departures, _, failedSites, err := resolveDepartures(ctx, departureQueryFromGetDeparturesParams(request.Params))
+	if err != nil {
+		return api.GetDepartures500TextResponse("Error fetching departure data: " + err.Error()), nil
+	}
+	return api.GetDepartures200TextResponse(renderDepartures(departures, request.Params.SiteId, failedSites)), nil
 }
 
-func handleDepartures(w http.ResponseWriter, r *http.Request) {
-	departures, siteID, err := getDepartures(r)
+func (apiServer) GetDeparturesJSON(ctx context.Context, request api.GetDeparturesJSONRequestObject) (api.GetDeparturesJSONResponseObject, error) {
+	departures, age, failedSites, err := resolveDepartures(ctx, departureQueryFromGetDeparturesJSONParams(request.Params))
 	if err != nil {
-		http.Error(w, "Error fetching departure data: "+err.Error(), http.StatusInternalServerError)
-		return
+		return api.GetDeparturesJSON500TextResponse("Error fetching departure data: " + err.Error()), nil
 	}
 
-	prettyPrintDepartures(w, departures, siteID)
+	return api.GetDeparturesJSON200JSONResponse{
+		Body: toAPIDepartures(departures),
+		Headers: api.GetDeparturesJSON200ResponseHeaders{
+			Age:            int(age.Seconds()),
+			CacheControl:   cacheControlHeader(age),
+			XPartialErrors: strings.Join(failedSites, ","),
+		},
+	}, nil
 }
 
-func handleDeparturesJSON(w http.ResponseWriter, r *http.Request) {
-	departures, _, err := getDepartures(r)
-	if err != nil {
-		http.Error(w, "Error fetching departure data: "+err.Error(), http.StatusInternalServerError)
-		return
+// cacheControlHeader reports how much longer a response fetched age ago
+// remains fresh, so clients and CDNs can poll sensibly.
+func cacheControlHeader(age time.Duration) string {
+	maxAge := departuresCache.TTL() - age
+	if maxAge < 0 {
+		maxAge = 0
 	}
+	return fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+}
 
-	if len(departures) == 0 {
-		departures = []Departure{}
+// toAPIDepartures converts provider departures into the generated
+// api.Departure shape used by the JSON response.
+func toAPIDepartures(departures []Departure) []api.Departure {
+	out := make([]api.Departure, 0, len(departures))
+	for _, d := range departures {
+		destination, direction, siteID := d.Destination, d.Direction, d.SiteID
+		scheduled, expected := d.Scheduled.Time, d.Expected.Time
+		lineID, designation := d.Line.ID, d.Line.Designation
+
+		out = append(out, api.Departure{
+			Destination: &destination,
+			Direction:   &direction,
+			Scheduled:   &scheduled,
+			Expected:    &expected,
+			Line:        &api.Line{Id: &lineID, Designation: &designation},
+			SiteId:      &siteID,
+		})
+	}
+	return out
+}
+
+// departureQuery is the provider-agnostic shape resolveDepartures and its
+// callers work with. It exists because the generated api.GetDeparturesParams
+// and api.GetDeparturesJSONParams are distinct types (oapi-codegen mints a
+// separate Provider enum per operation), and because the gtfs-rt/stream
+// endpoints aren't part of the OpenAPI spec and so have no generated params
+// type to start from.
+type departureQuery struct {
+	SiteId    string
+	LineId    *int
+	Direction *string
+	Provider  *string
+}
+
+func departureQueryFromGetDeparturesParams(p api.GetDeparturesParams) departureQuery {
+	var providerName *string
+	if p.Provider != nil {
+		name := string(*p.Provider)
+		providerName = &name
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(departures)
+	return departureQuery{SiteId: p.SiteId, LineId: p.LineId, Direction: p.Direction, Provider: providerName}
 }
 
-func getDepartures(r *http.Request) ([]Departure, string, error) {
-	siteID := r.URL.Query().Get("siteId")
+func departureQueryFromGetDeparturesJSONParams(p api.GetDeparturesJSONParams) departureQuery {
+	var providerName *string
+	if p.Provider != nil {
+		name := string(*p.Provider)
+		providerName = &name
+	}
+	return departureQuery{SiteId: p.SiteId, LineId: p.LineId, Direction: p.Direction, Provider: providerName}
+}
+
+// parseDepartureQuery validates and extracts siteId/lineId/direction/provider
+// from r for the gtfs-rt and stream endpoints, which sit outside the OpenAPI
+// spec and so get no validation from the generated api.ServerInterfaceWrapper.
+func parseDepartureQuery(r *http.Request) (departureQuery, error) {
+	q := r.URL.Query()
+
+	siteID := q.Get("siteId")
 	if siteID == "" {
-		return nil, "", fmt.Errorf("siteId query parameter is required")
+		return departureQuery{}, fmt.Errorf("query argument siteId is required, but not found")
+	}
+
+	query := departureQuery{SiteId: siteID}
+
+	if raw := q.Get("lineId"); raw != "" {
+		lineID, err := strconv.Atoi(raw)
+		if err != nil {
+			return departureQuery{}, fmt.Errorf("invalid format for parameter lineId: %w", err)
+		}
+		query.LineId = &lineID
 	}
 
-	response, err := fetchDepartures(siteID)
+	if raw := q.Get("direction"); raw != "" {
+		query.Direction = &raw
+	}
+
+	if raw := q.Get("provider"); raw != "" {
+		query.Provider = &raw
+	}
+
+	return query, nil
+}
+
+// resolveProvider picks the transit backend named by name, defaulting to
+// SL when name is nil.
+func resolveProvider(name *string) (provider.Provider, error) {
+	providerName := defaultProviderName
+	if name != nil {
+		providerName = *name
+	}
+	p, ok := providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", providerName)
+	}
+	return p, nil
+}
+
+// resolveDepartures fans out to every site in params.SiteId (comma-
+// separated) concurrently and returns the merged, filtered, sorted
+// departures; the age of the oldest contributing response; and the list
+// of sites that failed to return departures. An error is only returned if
+// the provider is unknown or every site failed.
+func resolveDepartures(ctx context.Context, params departureQuery) ([]Departure, time.Duration, []string, error) {
+	siteIDs := strings.Split(params.SiteId, ",")
+
+	p, err := resolveProvider(params.Provider)
 	if err != nil {
-		return nil, siteID, err
+		return nil, 0, nil, err
+	}
+
+	var opts provider.Options
+	if params.LineId != nil {
+		opts.LineID = strconv.Itoa(*params.LineId)
+	}
+	if params.Direction != nil {
+		opts.Direction = *params.Direction
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	var departures []Departure
+	var failedSites []string
+	var maxAge time.Duration
+
+	results := fetchDeparturesForSites(fetchCtx, p, siteIDs, opts)
+	for _, siteID := range siteIDs {
+		result := results[siteID]
+		if result.err != nil {
+			failedSites = append(failedSites, siteID)
+			continue
+		}
+		if result.age > maxAge {
+			maxAge = result.age
+		}
+		for _, d := range result.departures {
+			d.SiteID = siteID
+			departures = append(departures, d)
+		}
 	}
 
-	lineID := r.URL.Query().Get("lineId")
-	direction := r.URL.Query().Get("direction")
+	if len(departures) == 0 && len(failedSites) == len(siteIDs) {
+		return nil, 0, failedSites, fmt.Errorf("all sites failed: %s", strings.Join(failedSites, ", "))
+	}
 
-	filteredDepartures := filterDepartures(response.Departures, lineID, direction)
+	filtered := filterDepartures(departures, params.LineId, params.Direction)
 
-	sort.Slice(filteredDepartures, func(i, j int) bool {
-		return filteredDepartures[i].Expected.Before(filteredDepartures[j].Expected.Time)
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Expected.Before(filtered[j].Expected.Time)
 	})
 
-	return filteredDepartures, siteID, nil
+	return filtered, maxAge, failedSites, nil
 }
 
-func fetchDepartures(siteID string) (Response, error) {
-	var response Response
+// fetchResult is the outcome of fetching departures for a single site.
+type fetchResult struct {
+	departures []Departure
+	age        time.Duration
+	err        error
+}
 
-	url := fmt.Sprintf(baseURL, siteID)
-	resp, err := http.Get(url)
-	if err != nil {
-		return response, fmt.Errorf("error making request: %v", err)
+// fetchDeparturesForSites fetches departures for each of siteIDs
+// concurrently, bounded by fanoutConcurrency workers, and returns a
+// per-site result map.
+func fetchDeparturesForSites(ctx context.Context, p provider.Provider, siteIDs []string, opts provider.Options) map[string]fetchResult {
+	results := make(map[string]fetchResult, len(siteIDs))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, fanoutConcurrency)
+	for _, siteID := range siteIDs {
+		siteID := siteID
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			departures, age, err := fetchDepartures(ctx, p, siteID, opts)
+			mu.Lock()
+			results[siteID] = fetchResult{departures: departures, age: age, err: err}
+			mu.Unlock()
+		}()
 	}
-	defer resp.Body.Close()
+	wg.Wait()
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return response, fmt.Errorf("error reading response body: %v", err)
+	return results
+}
+
+// fetchDepartures returns the departures for siteID from p, serving from
+// departuresCache when a fresh response is available instead of calling
+// out to the upstream backend. The returned duration is the age of the
+// response (zero for a fresh upstream fetch).
+func fetchDepartures(ctx context.Context, p provider.Provider, siteID string, opts provider.Options) ([]Departure, time.Duration, error) {
+	cacheKey := fmt.Sprintf("%T|%s", p, siteID)
+	if cached, age, ok := departuresCache.Get(cacheKey); ok {
+		return cached.([]Departure), age, nil
 	}
 
-	err = json.Unmarshal(body, &response)
+	departures, err := p.FetchDepartures(ctx, siteID, opts)
 	if err != nil {
-		return response, fmt.Errorf("error parsing JSON: %v", err)
+		return nil, 0, err
 	}
 
-	return response, nil
+	departuresCache.Set(cacheKey, departures)
+	return departures, 0, nil
 }
 
-func filterDepartures(departures []Departure, lineID, direction string) []Departure {
+// filterDepartures applies the already-validated lineID/direction filters
+// to departures.
+func filterDepartures(departures []Departure, lineID *int, direction *string) []Departure {
 	var filtered []Departure
 
 	for _, d := range departures {
-		if lineID != "" {
-			id, err := strconv.Atoi(lineID)
-			if err != nil || d.Line.ID != id {
-				continue
-			}
+		if lineID != nil && d.Line.ID != *lineID {
+			continue
 		}
-
-		if direction != "" && d.Direction != direction {
+		if direction != nil && d.Direction != *direction {
 			continue
 		}
 
@@ -147,29 +415,47 @@ func filterDepartures(departures []Departure, lineID, direction string) []Depart
 	return filtered
 }
 
-func prettyPrintDepartures(w http.ResponseWriter, departures []Departure, siteID string) {
+// renderDepartures formats departures as the plain-text board served by
+// GET /departures. failedSites lists any requested sites that errored out
+// of the multi-site fan-out; unlike the JSON endpoint, plain text has no
+// headers to carry that in, so it's surfaced as a trailing note instead.
+func renderDepartures(departures []Departure, siteID string, failedSites []string) string {
+	var b strings.Builder
+
 	if len(departures) == 0 {
-		fmt.Fprintf(w, "No departures found matching the criteria for site ID: %s\n", siteID)
-		return
+		fmt.Fprintf(&b, "No departures found matching the criteria for site ID: %s\n", siteID)
+		if len(failedSites) > 0 {
+			fmt.Fprintf(&b, "Note: no data for site(s): %s\n", strings.Join(failedSites, ", "))
+		}
+		return b.String()
 	}
 
-	fmt.Fprintf(w, "Upcoming Departures for site ID %s (sorted by expected departure time):\n", siteID)
-	fmt.Fprintln(w, "--------------------")
+	fmt.Fprintf(&b, "Upcoming Departures for site ID %s (sorted by expected departure time):\n", siteID)
+	fmt.Fprintln(&b, "--------------------")
 
 	for _, d := range departures {
 		scheduledTime := d.Scheduled.Format("15:04")
 		expectedTime := d.Expected.Format("15:04")
 
-		fmt.Fprintf(w, "Line %s (ID: %d) to %s\n", d.Line.Designation, d.Line.ID, d.Destination)
-		fmt.Fprintf(w, "  Direction: %s\n", d.Direction)
-		fmt.Fprintf(w, "  Scheduled: %s\n", scheduledTime)
-		fmt.Fprintf(w, "  Expected:  %s\n", expectedTime)
+		fmt.Fprintf(&b, "Line %s (ID: %d) to %s\n", d.Line.Designation, d.Line.ID, d.Destination)
+		if d.SiteID != "" && strings.Contains(siteID, ",") {
+			fmt.Fprintf(&b, "  Site ID:   %s\n", d.SiteID)
+		}
+		fmt.Fprintf(&b, "  Direction: %s\n", d.Direction)
+		fmt.Fprintf(&b, "  Scheduled: %s\n", scheduledTime)
+		fmt.Fprintf(&b, "  Expected:  %s\n", expectedTime)
 
 		if scheduledTime != expectedTime {
 			delay := d.Expected.Sub(d.Scheduled.Time)
-			fmt.Fprintf(w, "  Delay:     %d minutes\n", int(delay.Minutes()))
+			fmt.Fprintf(&b, "  Delay:     %d minutes\n", int(delay.Minutes()))
 		}
 
-		fmt.Fprintln(w, "--------------------")
+		fmt.Fprintln(&b, "--------------------")
+	}
+
+	if len(failedSites) > 0 {
+		fmt.Fprintf(&b, "Note: no data for site(s): %s\n", strings.Join(failedSites, ", "))
 	}
+
+	return b.String()
 }
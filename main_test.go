@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mooncos/sl-departures-api/cache"
+	"github.com/mooncos/sl-departures-api/provider"
+)
+
+func TestMain(m *testing.M) {
+	departuresCache = cache.New(time.Minute)
+	metadataCache = cache.New(time.Minute)
+	fanoutConcurrency = 8
+	fetchTimeout = time.Second
+	os.Exit(m.Run())
+}
+
+// perSiteProvider is a test double whose FetchDepartures outcome is
+// determined by the requested siteID, so a single provider instance can
+// simulate a mix of succeeding and failing sites.
+type perSiteProvider struct {
+	fail map[string]bool
+}
+
+func (p perSiteProvider) FetchDepartures(ctx context.Context, siteID string, opts provider.Options) ([]provider.Departure, error) {
+	if p.fail[siteID] {
+		return nil, errors.New("upstream error")
+	}
+	return []provider.Departure{{Destination: "Test", Line: provider.Line{ID: 1}}}, nil
+}
+
+func withTestProvider(t *testing.T, p provider.Provider) string {
+	name := "test-" + t.Name()
+	providers[name] = p
+	t.Cleanup(func() { delete(providers, name) })
+	return name
+}
+
+func TestResolveDeparturesPartialFailure(t *testing.T) {
+	name := withTestProvider(t, perSiteProvider{fail: map[string]bool{"bad": true}})
+
+	departures, _, failedSites, err := resolveDepartures(context.Background(), departureQuery{
+		SiteId:   "good,bad",
+		Provider: &name,
+	})
+	if err != nil {
+		t.Fatalf("resolveDepartures returned error with a partial failure: %v", err)
+	}
+	if len(departures) != 1 {
+		t.Fatalf("got %d departures, want 1 from the succeeding site", len(departures))
+	}
+	if len(failedSites) != 1 || failedSites[0] != "bad" {
+		t.Fatalf("failedSites = %v, want [bad]", failedSites)
+	}
+}
+
+func TestResolveDeparturesTotalFailure(t *testing.T) {
+	name := withTestProvider(t, perSiteProvider{fail: map[string]bool{"bad1": true, "bad2": true}})
+
+	departures, _, failedSites, err := resolveDepartures(context.Background(), departureQuery{
+		SiteId:   "bad1,bad2",
+		Provider: &name,
+	})
+	if err == nil {
+		t.Fatal("resolveDepartures should error when every site fails")
+	}
+	if departures != nil {
+		t.Errorf("departures = %v, want nil", departures)
+	}
+	if len(failedSites) != 2 {
+		t.Errorf("failedSites = %v, want both sites", failedSites)
+	}
+}
+
+func TestRenderDeparturesNotesFailedSites(t *testing.T) {
+	departures := []Departure{{Destination: "Test", Line: Line{ID: 1}}}
+
+	text := renderDepartures(departures, "good,bad", []string{"bad"})
+	if !strings.Contains(text, "Note: no data for site(s): bad") {
+		t.Fatalf("renderDepartures output missing failed-site note: %q", text)
+	}
+
+	empty := renderDepartures(nil, "bad", []string{"bad"})
+	if !strings.Contains(empty, "Note: no data for site(s): bad") {
+		t.Fatalf("renderDepartures output missing failed-site note for empty result: %q", empty)
+	}
+}
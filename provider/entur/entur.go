@@ -0,0 +1,158 @@
+// Package entur implements provider.Provider against Entur's JourneyPlanner
+// GraphQL API, covering public transport outside Stockholm (Norway).
+package entur
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mooncos/sl-departures-api/provider"
+)
+
+const graphQLEndpoint = "https://api.entur.io/journey-planner/v3/graphql"
+
+// clientNameEnv names the client as required by Entur's terms of use. See
+// https://developer.entur.org/pages-intro-authentication.
+const clientNameEnv = "ENTUR_CLIENT_NAME"
+
+const query = `
+query($id: String!, $numberOfDepartures: Int!) {
+  stopPlace(id: $id) {
+    estimatedCalls(numberOfDepartures: $numberOfDepartures) {
+      aimedDepartureTime
+      expectedDepartureTime
+      destinationDisplay { frontText }
+      serviceJourney {
+        directionType
+        line { id publicCode }
+      }
+    }
+  }
+}`
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data struct {
+		StopPlace struct {
+			EstimatedCalls []estimatedCall `json:"estimatedCalls"`
+		} `json:"stopPlace"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type estimatedCall struct {
+	AimedDepartureTime    string `json:"aimedDepartureTime"`
+	ExpectedDepartureTime string `json:"expectedDepartureTime"`
+	DestinationDisplay    struct {
+		FrontText string `json:"frontText"`
+	} `json:"destinationDisplay"`
+	ServiceJourney struct {
+		DirectionType string `json:"directionType"`
+		Line          struct {
+			ID         string `json:"id"`
+			PublicCode string `json:"publicCode"`
+		} `json:"line"`
+	} `json:"serviceJourney"`
+}
+
+// Provider fetches departures from Entur's JourneyPlanner GraphQL API over
+// a connection-pooled http.Client, retrying 5xx responses and network
+// errors with exponential backoff and jitter.
+// siteID is expected to be an Entur NSR stop place ID, e.g.
+// "NSR:StopPlace:418".
+type Provider struct {
+	client *http.Client
+}
+
+// New returns an Entur Provider.
+func New() *Provider {
+	return &Provider{client: provider.NewHTTPClient()}
+}
+
+func (p *Provider) FetchDepartures(ctx context.Context, siteID string, opts provider.Options) ([]provider.Departure, error) {
+	reqBody, err := json.Marshal(graphQLRequest{
+		Query: query,
+		Variables: map[string]any{
+			"id":                 siteID,
+			"numberOfDepartures": 50,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error building request body: %v", err)
+	}
+
+	body, err := provider.DoWithRetry(ctx, p.client, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphQLEndpoint, bytes.NewReader(reqBody))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("ET-Client-Name", os.Getenv(clientNameEnv))
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed graphQLResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %v", err)
+	}
+	if len(parsed.Errors) > 0 {
+		return nil, fmt.Errorf("entur API error: %s", parsed.Errors[0].Message)
+	}
+
+	departures := make([]provider.Departure, 0, len(parsed.Data.StopPlace.EstimatedCalls))
+	for _, call := range parsed.Data.StopPlace.EstimatedCalls {
+		scheduled, err := parseTime(call.AimedDepartureTime)
+		if err != nil {
+			continue
+		}
+		expected, err := parseTime(call.ExpectedDepartureTime)
+		if err != nil {
+			expected = scheduled
+		}
+
+		departures = append(departures, provider.Departure{
+			Destination: call.DestinationDisplay.FrontText,
+			Direction:   call.ServiceJourney.DirectionType,
+			Scheduled:   provider.CustomTime{Time: scheduled},
+			Expected:    provider.CustomTime{Time: expected},
+			Line: provider.Line{
+				ID:          lineID(call.ServiceJourney.Line.ID),
+				Designation: call.ServiceJourney.Line.PublicCode,
+			},
+		})
+	}
+
+	return departures, nil
+}
+
+// parseTime parses Entur's RFC3339 timestamps.
+func parseTime(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, s)
+}
+
+// lineID extracts the numeric suffix of an Entur line ID, e.g.
+// "SKY:Line:1300" -> 1300, so it fits the module's integer Line.ID.
+func lineID(enturID string) int {
+	parts := strings.Split(enturID, ":")
+	id, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,23 @@
+package entur
+
+import "testing"
+
+func TestLineID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want int
+	}{
+		{"typical Entur line ID", "SKY:Line:1300", 1300},
+		{"no trailing digits", "SKY:Line:RUT", 0},
+		{"no colon", "1300", 1300},
+		{"empty", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineID(tt.id); got != tt.want {
+				t.Errorf("lineID(%q) = %d, want %d", tt.id, got, tt.want)
+			}
+		})
+	}
+}
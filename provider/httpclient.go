@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// maxRetries is the number of additional attempts GetWithRetry makes after a
+// failed request, on top of the first one.
+const maxRetries = 2
+
+// perAttemptTimeout bounds a single HTTP round trip; the overall deadline is
+// still governed by the context passed to GetWithRetry.
+const perAttemptTimeout = 3 * time.Second
+
+// NewHTTPClient returns a connection-pooled *http.Client suitable for a
+// Provider to fetch departures from its upstream backend, with a per-attempt
+// timeout bounding each individual round trip made via GetWithRetry.
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: perAttemptTimeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// retryableError wraps an error from a failed attempt that's worth retrying
+// (network errors, 5xx responses).
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+func isRetryable(err error) bool {
+	_, ok := err.(*retryableError)
+	return ok
+}
+
+// GetWithRetry performs an HTTP GET against url using client, retrying
+// network errors and 5xx responses up to maxRetries additional times with
+// exponential backoff and jitter. 4xx responses are returned immediately
+// without retrying.
+func GetWithRetry(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	return DoWithRetry(ctx, client, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	})
+}
+
+// DoWithRetry builds and sends a request via newRequest, retrying network
+// errors and 5xx responses up to maxRetries additional times with
+// exponential backoff and jitter. newRequest is called again on every
+// attempt so callers with a request body (e.g. POST) can hand back a fresh
+// body reader each time. 4xx responses are returned immediately without
+// retrying.
+func DoWithRetry(ctx context.Context, client *http.Client, newRequest func() (*http.Request, error)) ([]byte, error) {
+	var body []byte
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			if waitErr := sleepBackoff(ctx, attempt); waitErr != nil {
+				return nil, waitErr
+			}
+		}
+
+		body, err = doRequest(ctx, client, newRequest)
+		if err == nil {
+			return body, nil
+		}
+		if !isRetryable(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func doRequest(ctx context.Context, client *http.Client, newRequest func() (*http.Request, error)) ([]byte, error) {
+	req, err := newRequest()
+	if err != nil {
+		return nil, fmt.Errorf("error building request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &retryableError{fmt.Errorf("error making request: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &retryableError{fmt.Errorf("error reading response body: %v", err)}
+	}
+
+	if resp.StatusCode >= 500 {
+		return nil, &retryableError{fmt.Errorf("upstream returned %s", resp.Status)}
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("upstream returned %s", resp.Status)
+	}
+
+	return body, nil
+}
+
+// sleepBackoff waits an exponentially increasing, jittered delay before the
+// next attempt (100ms, 200ms, 400ms, ... +/-50% jitter), returning early if
+// ctx is done.
+func sleepBackoff(ctx context.Context, attempt int) error {
+	base := 100 * time.Millisecond * time.Duration(1<<uint(attempt-1))
+	jitter := time.Duration(rand.Int63n(int64(base)))
+	delay := base/2 + jitter
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
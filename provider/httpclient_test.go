@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetWithRetrySucceedsAfterTransientFailures(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	body, err := GetWithRetry(context.Background(), server.Client(), server.URL)
+	if err != nil {
+		t.Fatalf("GetWithRetry returned error: %v", err)
+	}
+	if string(body) != "ok" {
+		t.Fatalf("body = %q, want %q", body, "ok")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("attempts = %d, want 3", got)
+	}
+}
+
+func TestGetWithRetryGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := GetWithRetry(context.Background(), server.Client(), server.URL)
+	if err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if want := int32(maxRetries + 1); atomic.LoadInt32(&attempts) != want {
+		t.Fatalf("attempts = %d, want %d", attempts, want)
+	}
+}
+
+func TestGetWithRetryDoesNotRetry4xx(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := GetWithRetry(context.Background(), server.Client(), server.URL)
+	if err == nil {
+		t.Fatal("expected error for 404 response")
+	}
+	if !strings.Contains(err.Error(), "404") {
+		t.Fatalf("error = %q, want it to mention 404", err)
+	}
+	if atomic.LoadInt32(&attempts) != 1 {
+		t.Fatalf("attempts = %d, want 1 (no retry on 4xx)", attempts)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if isRetryable(nil) {
+		t.Fatal("isRetryable(nil) should be false")
+	}
+	if isRetryable(context.DeadlineExceeded) {
+		t.Fatal("a plain error should not be retryable")
+	}
+	if !isRetryable(&retryableError{err: context.DeadlineExceeded}) {
+		t.Fatal("a retryableError should be retryable")
+	}
+}
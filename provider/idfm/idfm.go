@@ -0,0 +1,152 @@
+// Package idfm implements provider.Provider against Île-de-France
+// Mobilités' PRIM API, which exposes SIRI StopMonitoring responses for
+// the Paris region.
+package idfm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mooncos/sl-departures-api/provider"
+)
+
+const stopMonitoringURL = "https://prim.iledefrance-mobilites.fr/marketplace/stop-monitoring?MonitoringRef=%s"
+
+// apiKeyEnv configures the PRIM API key, sent as the "apikey" header.
+const apiKeyEnv = "IDFM_API_KEY"
+
+type stopMonitoringResponse struct {
+	Siri struct {
+		ServiceDelivery struct {
+			StopMonitoringDelivery []struct {
+				MonitoredStopVisit []struct {
+					MonitoredVehicleJourney struct {
+						LineRef struct {
+							Value string `json:"value"`
+						} `json:"LineRef"`
+						PublishedLineName []struct {
+							Value string `json:"value"`
+						} `json:"PublishedLineName"`
+						DirectionName []struct {
+							Value string `json:"value"`
+						} `json:"DirectionName"`
+						MonitoredCall struct {
+							DestinationDisplay []struct {
+								Value string `json:"value"`
+							} `json:"DestinationDisplay"`
+							AimedDepartureTime    string `json:"AimedDepartureTime"`
+							ExpectedDepartureTime string `json:"ExpectedDepartureTime"`
+						} `json:"MonitoredCall"`
+					} `json:"MonitoredVehicleJourney"`
+				} `json:"MonitoredStopVisit"`
+			} `json:"StopMonitoringDelivery"`
+		} `json:"ServiceDelivery"`
+	} `json:"Siri"`
+}
+
+// Provider fetches departures from the IDFM PRIM stop-monitoring API over a
+// connection-pooled http.Client, retrying 5xx responses and network errors
+// with exponential backoff and jitter. siteID is a SIRI monitoring ref,
+// e.g. "STIF:StopPoint:Q:411194:".
+type Provider struct {
+	client *http.Client
+}
+
+// New returns an IDFM Provider.
+func New() *Provider {
+	return &Provider{client: provider.NewHTTPClient()}
+}
+
+func (p *Provider) FetchDepartures(ctx context.Context, siteID string, opts provider.Options) ([]provider.Departure, error) {
+	requestURL := fmt.Sprintf(stopMonitoringURL, url.QueryEscape(siteID))
+	body, err := provider.DoWithRetry(ctx, p.client, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("apikey", os.Getenv(apiKeyEnv))
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed stopMonitoringResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %v", err)
+	}
+
+	var departures []provider.Departure
+	for _, delivery := range parsed.Siri.ServiceDelivery.StopMonitoringDelivery {
+		for _, visit := range delivery.MonitoredStopVisit {
+			call := visit.MonitoredVehicleJourney.MonitoredCall
+
+			scheduled, err := time.Parse(time.RFC3339, call.AimedDepartureTime)
+			if err != nil {
+				continue
+			}
+			expected, err := time.Parse(time.RFC3339, call.ExpectedDepartureTime)
+			if err != nil {
+				expected = scheduled
+			}
+
+			journey := visit.MonitoredVehicleJourney
+
+			var destination, direction string
+			if len(call.DestinationDisplay) > 0 {
+				destination = call.DestinationDisplay[0].Value
+			}
+			if len(journey.DirectionName) > 0 {
+				direction = journey.DirectionName[0].Value
+			}
+
+			designation := journey.LineRef.Value
+			if len(journey.PublishedLineName) > 0 {
+				designation = journey.PublishedLineName[0].Value
+			}
+
+			departures = append(departures, provider.Departure{
+				Destination: destination,
+				Direction:   direction,
+				Scheduled:   provider.CustomTime{Time: scheduled},
+				Expected:    provider.CustomTime{Time: expected},
+				Line: provider.Line{
+					ID:          lineID(journey.LineRef.Value),
+					Designation: designation,
+				},
+			})
+		}
+	}
+
+	return departures, nil
+}
+
+// lineID extracts the numeric suffix of an IDFM SIRI line ref, e.g.
+// "STIF:Line::C01742:" -> 1742, so it fits the module's integer Line.ID.
+// IDFM line refs mix letters and digits (bus/tram codes like "C01742"), so
+// this is best-effort and falls back to 0, like entur/navitia's lineID.
+func lineID(idfmRef string) int {
+	parts := strings.Split(idfmRef, ":")
+
+	var last string
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] != "" {
+			last = parts[i]
+			break
+		}
+	}
+
+	digits := strings.TrimLeft(last, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	id, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+	return id
+}
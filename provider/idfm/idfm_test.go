@@ -0,0 +1,23 @@
+package idfm
+
+import "testing"
+
+func TestLineID(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+		want int
+	}{
+		{"letter-prefixed digits", "STIF:Line::C01742:", 1742},
+		{"no trailing digits", "STIF:Line::TRAM:", 0},
+		{"plain digits", "STIF:Line::123:", 123},
+		{"empty", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineID(tt.ref); got != tt.want {
+				t.Errorf("lineID(%q) = %d, want %d", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package navitia implements provider.Provider against Navitia's
+// stop_areas departures API (used by many French transit networks).
+package navitia
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mooncos/sl-departures-api/provider"
+)
+
+const baseURL = "https://api.navitia.io/v1/coverage/%s/stop_areas/%s/departures"
+
+// apiKeyEnv and coverageEnv configure the Navitia authentication token and
+// coverage region (e.g. "sncf", "fr-idf") respectively.
+const (
+	apiKeyEnv   = "NAVITIA_API_KEY"
+	coverageEnv = "NAVITIA_COVERAGE"
+)
+
+const navitiaTimeFormat = "20060102T150405"
+
+type departuresResponse struct {
+	Departures []struct {
+		StopDateTime struct {
+			DepartureDateTime     string `json:"departure_date_time"`
+			BaseDepartureDateTime string `json:"base_departure_date_time"`
+		} `json:"stop_date_time"`
+		DisplayInformations struct {
+			Direction string `json:"direction"`
+			Label     string `json:"label"`
+			Code      string `json:"code"`
+		} `json:"display_informations"`
+		Route struct {
+			Line struct {
+				ID   string `json:"id"`
+				Code string `json:"code"`
+			} `json:"line"`
+		} `json:"route"`
+	} `json:"departures"`
+}
+
+// Provider fetches departures from the Navitia API for a configured
+// coverage region over a connection-pooled http.Client, retrying 5xx
+// responses and network errors with exponential backoff and jitter.
+// siteID is a Navitia stop_area id, e.g. "stop_area:OIF:SA:8775860".
+type Provider struct {
+	client *http.Client
+}
+
+// New returns a Navitia Provider.
+func New() *Provider {
+	return &Provider{client: provider.NewHTTPClient()}
+}
+
+func (p *Provider) FetchDepartures(ctx context.Context, siteID string, opts provider.Options) ([]provider.Departure, error) {
+	coverage := os.Getenv(coverageEnv)
+	if coverage == "" {
+		coverage = "sncf"
+	}
+
+	requestURL := fmt.Sprintf(baseURL, coverage, url.PathEscape(siteID))
+	body, err := provider.DoWithRetry(ctx, p.client, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(os.Getenv(apiKeyEnv), "")
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed departuresResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %v", err)
+	}
+
+	departures := make([]provider.Departure, 0, len(parsed.Departures))
+	for _, d := range parsed.Departures {
+		expected, err := time.Parse(navitiaTimeFormat, d.StopDateTime.DepartureDateTime)
+		if err != nil {
+			continue
+		}
+		scheduled := expected
+		if base, err := time.Parse(navitiaTimeFormat, d.StopDateTime.BaseDepartureDateTime); err == nil {
+			scheduled = base
+		}
+
+		destination := d.DisplayInformations.Direction
+		if destination == "" {
+			destination = d.DisplayInformations.Label
+		}
+
+		departures = append(departures, provider.Departure{
+			Destination: destination,
+			// Navitia's display_informations has no distinct direction
+			// code separate from the destination text (unlike entur's
+			// directionType or idfm's DirectionName), so Direction is
+			// left unset here rather than duplicating Destination into
+			// it, which would make ?direction= filtering useless.
+			Scheduled: provider.CustomTime{Time: scheduled},
+			Expected:  provider.CustomTime{Time: expected},
+			Line: provider.Line{
+				ID:          lineID(d.Route.Line.ID),
+				Designation: d.DisplayInformations.Code,
+			},
+		})
+	}
+
+	return departures, nil
+}
+
+// lineID parses a Navitia line ID that is a plain integer so it fits the
+// module's integer Line.ID. Any other ID, including one with digits inside
+// it such as "line:RATP:C01742", yields 0.
+func lineID(navitiaID string) int {
+	id, err := strconv.Atoi(navitiaID)
+	if err != nil {
+		return 0
+	}
+	return id
+}
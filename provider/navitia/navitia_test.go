@@ -0,0 +1,22 @@
+package navitia
+
+import "testing"
+
+func TestLineID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want int
+	}{
+		{"plain digits", "1742", 1742},
+		{"non-numeric ID", "line:RATP:C01742", 0},
+		{"empty", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineID(tt.id); got != tt.want {
+				t.Errorf("lineID(%q) = %d, want %d", tt.id, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Package provider defines the common shape transit backends are
+// normalized into, so the HTTP layer can treat SL, Entur, Navitia and IDFM
+// the same way.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Options carries the request-scoped filters a Provider may apply when
+// fetching departures. Providers that can't filter server-side are free to
+// ignore fields they don't support; the caller re-applies them afterwards.
+type Options struct {
+	LineID    string
+	Direction string
+}
+
+// Provider fetches upcoming departures for a site/stop from a transit
+// backend and normalizes them into Departure.
+type Provider interface {
+	FetchDepartures(ctx context.Context, siteID string, opts Options) ([]Departure, error)
+}
+
+// Departure is the backend-agnostic shape every Provider normalizes its
+// responses into.
+type Departure struct {
+	Destination string     `json:"destination"`
+	Direction   string     `json:"direction"`
+	Scheduled   CustomTime `json:"scheduled"`
+	Expected    CustomTime `json:"expected"`
+	Line        Line       `json:"line"`
+	SiteID      string     `json:"siteId,omitempty"`
+}
+
+// Line identifies the transit line serving a Departure.
+type Line struct {
+	ID          int    `json:"id"`
+	Designation string `json:"designation"`
+}
+
+// CustomTime marshals/unmarshals times in the "2006-01-02T15:04:05" format
+// used throughout the module's JSON responses.
+type CustomTime struct {
+	time.Time
+}
+
+func (ct CustomTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ct.Format("2006-01-02T15:04:05"))
+}
+
+func (ct *CustomTime) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	s = s[1 : len(s)-1]
+	t, err := time.Parse("2006-01-02T15:04:05", s)
+	if err != nil {
+		return err
+	}
+	ct.Time = t
+	return nil
+}
@@ -0,0 +1,47 @@
+// Package sl implements provider.Provider against SL's (Storstockholms
+// Lokaltrafik) public departures API. This is the module's original,
+// default backend.
+package sl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mooncos/sl-departures-api/provider"
+)
+
+const baseURL = "https://transport.integration.sl.se/v1/sites/%s/departures"
+
+type response struct {
+	Departures []provider.Departure `json:"departures"`
+}
+
+// Provider fetches departures from transport.integration.sl.se over a
+// connection-pooled http.Client, retrying 5xx responses and network errors
+// with exponential backoff and jitter.
+type Provider struct {
+	client *http.Client
+}
+
+// New returns an SL Provider.
+func New() *Provider {
+	return &Provider{client: provider.NewHTTPClient()}
+}
+
+func (p *Provider) FetchDepartures(ctx context.Context, siteID string, opts provider.Options) ([]provider.Departure, error) {
+	url := fmt.Sprintf(baseURL, siteID)
+
+	body, err := provider.GetWithRetry(ctx, p.client, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed response
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %v", err)
+	}
+
+	return parsed.Departures, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	minStreamInterval     = 5 * time.Second
+	maxStreamInterval     = 5 * time.Minute
+	defaultStreamInterval = 15 * time.Second
+)
+
+// handleDeparturesStream upgrades to Server-Sent Events and pushes the
+// current departures for the requested site(s) every interval until the
+// client disconnects. Because fetchDepartures is backed by
+// departuresCache, many concurrent SSE clients watching the same site
+// share a single upstream fetch per TTL window.
+func handleDeparturesStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	params, err := parseDepartureQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	interval := parseStreamInterval(r)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := r.Context()
+	var id int
+
+	for {
+		departures, _, _, err := resolveDepartures(ctx, params)
+		if err != nil {
+			fmt.Fprintf(w, "event: error\nid: %d\ndata: %s\n\n", id, err.Error())
+		} else {
+			payload, err := json.Marshal(toAPIDepartures(departures))
+			if err != nil {
+				fmt.Fprintf(w, "event: error\nid: %d\ndata: %s\n\n", id, err.Error())
+			} else {
+				fmt.Fprintf(w, "event: departures\nid: %d\ndata: %s\n\n", id, payload)
+			}
+		}
+		id++
+		flusher.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseStreamInterval reads the ?interval= query parameter (in seconds)
+// and clamps it to [minStreamInterval, maxStreamInterval], falling back
+// to defaultStreamInterval if absent or invalid.
+func parseStreamInterval(r *http.Request) time.Duration {
+	raw := r.URL.Query().Get("interval")
+	if raw == "" {
+		return defaultStreamInterval
+	}
+
+	seconds, err := time.ParseDuration(raw + "s")
+	if err != nil {
+		return defaultStreamInterval
+	}
+
+	if seconds < minStreamInterval {
+		return minStreamInterval
+	}
+	if seconds > maxStreamInterval {
+		return maxStreamInterval
+	}
+	return seconds
+}
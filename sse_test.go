@@ -0,0 +1,31 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseStreamInterval(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  time.Duration
+	}{
+		{"absent", "", defaultStreamInterval},
+		{"invalid", "interval=not-a-number", defaultStreamInterval},
+		{"within range", "interval=30", 30 * time.Second},
+		{"below minimum clamps up", "interval=1", minStreamInterval},
+		{"above maximum clamps down", "interval=3600", maxStreamInterval},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{URL: &url.URL{RawQuery: tt.query}}
+			if got := parseStreamInterval(r); got != tt.want {
+				t.Errorf("parseStreamInterval(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}